@@ -0,0 +1,123 @@
+// Command server wires the config, store, service, and handler layers
+// together with uber/fx and starts the HTTP server.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/mux"
+	"go.uber.org/fx"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/auth"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/handler"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/service"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/store"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/worker"
+)
+
+func main() {
+	fx.New(
+		fx.Provide(
+			config.LoadConfig,
+			config.NewStore,
+			store.NewRepository,
+			service.NewRedisClient,
+			fx.Annotate(service.NewRedisCache, fx.As(new(service.Cache))),
+			service.NewHub,
+			service.NewCachePolicy,
+			service.NewUserService,
+			fx.Annotate(auth.NewJWTIssuer, fx.As(new(auth.TokenIssuer))),
+			handler.NewUserHandler,
+			handler.NewAuthHandler,
+			handler.NewRouter,
+			func() *worker.WorkerPool { return worker.NewWorkerPool(5) },
+			newHTTPServer,
+		),
+		fx.Invoke(
+			runHub,
+			runWorkerPool,
+			runHTTPServer,
+			runConfigWatch,
+		),
+	).Run()
+}
+
+func newHTTPServer(cfg *config.Config, router *mux.Router) *http.Server {
+	return &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+}
+
+func runHub(lc fx.Lifecycle, hub *service.Hub) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go hub.Run(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+func runWorkerPool(lc fx.Lifecycle, pool *worker.WorkerPool) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			pool.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return pool.Stop(ctx)
+		},
+	})
+}
+
+// runConfigWatch applies configuration reloads triggered by SIGHUP or a
+// config file change to the already-constructed fx graph: the Store is
+// swapped so CORS and the WebSocket upgrader pick up the new values on
+// their next request, and the JWT issuer's key material is rebuilt in
+// place. Components that read Config directly at construction time (e.g.
+// AuthHandler's password hashing settings) still need a restart to pick up
+// a change.
+func runConfigWatch(lc fx.Lifecycle, cfg *config.Config, confStore *config.Store, issuer auth.TokenIssuer) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go cfg.Watch(ctx, func(reloaded *config.Config) {
+				if err := issuer.Reload(reloaded); err != nil {
+					log.Printf("config: reload failed, keeping previous JWT key material: %v", err)
+					return
+				}
+				confStore.Set(reloaded)
+				log.Printf("config: change detected, applied to CORS/WebSocket origin checks and the JWT issuer")
+			})
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+func runHTTPServer(lc fx.Lifecycle, srv *http.Server, rdb *redis.Client) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go srv.ListenAndServe()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := srv.Shutdown(ctx); err != nil {
+				return err
+			}
+			return rdb.Close()
+		},
+	})
+}
@@ -0,0 +1,278 @@
+// Package auth issues and validates JWTs and hashes/verifies passwords.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+)
+
+// HashAlgorithm selects which password hashing scheme new hashes are created with.
+type HashAlgorithm string
+
+const (
+	HashAlgorithmBcrypt   HashAlgorithm = "bcrypt"
+	HashAlgorithmArgon2id HashAlgorithm = "argon2id"
+)
+
+// Algorithm selects the signing algorithm used for issued tokens.
+type Algorithm string
+
+const (
+	AlgorithmHS256 Algorithm = "HS256"
+	AlgorithmRS256 Algorithm = "RS256"
+)
+
+const (
+	argon2idKeyLen  = 32
+	argon2idSaltLen = 16
+)
+
+// TokenType discriminates access tokens from refresh tokens so a token
+// issued for one purpose can't be replayed as the other.
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Claims are the custom JWT claims carried by access and refresh tokens.
+type Claims struct {
+	Roles     []string  `json:"roles"`
+	TokenType TokenType `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer is the service-boundary interface for issuing and validating
+// tokens, so UserService/handlers can be tested against a fake issuer.
+type TokenIssuer interface {
+	IssueAccessToken(user *model.User) (string, error)
+	IssueRefreshToken(user *model.User) (string, error)
+	ParseToken(tokenString string) (*Claims, error)
+	Reload(cfg *config.Config) error
+}
+
+// jwtKeyState is the signing/verification material and TTLs derived from a
+// single Config. It is rebuilt wholesale on Reload and swapped atomically so
+// in-flight Issue/Parse calls never observe a half-updated key.
+type jwtKeyState struct {
+	alg        Algorithm
+	hmacSecret []byte
+	rsaPrivate *rsa.PrivateKey
+	rsaPublic  *rsa.PublicKey
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// JWTIssuer is the production TokenIssuer, signing and verifying JWTs for
+// the configured algorithm. Its key material lives behind an atomic pointer
+// so a config reload (see Reload) takes effect without rebuilding the fx
+// graph.
+type JWTIssuer struct {
+	state atomic.Pointer[jwtKeyState]
+}
+
+// NewJWTIssuer builds a JWTIssuer from cfg. For HS256 it signs and verifies
+// with the shared JWTSecret; for RS256 it expects a PEM-encoded PKCS#1
+// private key at JWTPrivateKeyPath.
+func NewJWTIssuer(cfg *config.Config) (*JWTIssuer, error) {
+	issuer := &JWTIssuer{}
+	if err := issuer.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return issuer, nil
+}
+
+// Reload rebuilds the issuer's signing/verification key material and TTLs
+// from cfg and atomically swaps it in. Tokens issued or parsed concurrently
+// with a Reload use either the old or the new state, never a mix of both.
+func (t *JWTIssuer) Reload(cfg *config.Config) error {
+	state := &jwtKeyState{
+		alg:        Algorithm(cfg.JWTAlgorithm),
+		accessTTL:  cfg.JWTAccessTTL,
+		refreshTTL: cfg.JWTRefreshTTL,
+	}
+
+	switch state.alg {
+	case AlgorithmRS256:
+		priv, err := loadRSAPrivateKey(cfg.JWTPrivateKeyPath)
+		if err != nil {
+			return err
+		}
+		state.rsaPrivate = priv
+		state.rsaPublic = &priv.PublicKey
+	case AlgorithmHS256, "":
+		state.alg = AlgorithmHS256
+		if cfg.JWTSecret == "" {
+			return errors.New("auth: JWT_SECRET must not be empty")
+		}
+		state.hmacSecret = []byte(cfg.JWTSecret)
+	default:
+		return errors.New("auth: unsupported JWT algorithm " + string(state.alg))
+	}
+
+	t.state.Store(state)
+	return nil
+}
+
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("auth: invalid PEM in " + path)
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func (s *jwtKeyState) signingMethod() jwt.SigningMethod {
+	if s.alg == AlgorithmRS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodHS256
+}
+
+func (s *jwtKeyState) signingKey() interface{} {
+	if s.alg == AlgorithmRS256 {
+		return s.rsaPrivate
+	}
+	return s.hmacSecret
+}
+
+func (s *jwtKeyState) verifyKey() interface{} {
+	if s.alg == AlgorithmRS256 {
+		return s.rsaPublic
+	}
+	return s.hmacSecret
+}
+
+func (t *JWTIssuer) issue(user *model.User, ttl time.Duration, tokenType TokenType) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		Roles:     []string{user.Role},
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	state := t.state.Load()
+	token := jwt.NewWithClaims(state.signingMethod(), claims)
+	return token.SignedString(state.signingKey())
+}
+
+// IssueAccessToken returns a short-lived token asserting the user's identity and roles.
+func (t *JWTIssuer) IssueAccessToken(user *model.User) (string, error) {
+	state := t.state.Load()
+	return t.issue(user, state.accessTTL, TokenTypeAccess)
+}
+
+// IssueRefreshToken returns a long-lived token used only to mint new access tokens.
+func (t *JWTIssuer) IssueRefreshToken(user *model.User) (string, error) {
+	state := t.state.Load()
+	return t.issue(user, state.refreshTTL, TokenTypeRefresh)
+}
+
+// ParseToken validates the signature and expiry of tokenString and returns its claims.
+// It does not check TokenType; callers that care which kind of token they
+// were handed (e.g. refresh endpoints) must check claims.TokenType themselves.
+func (t *JWTIssuer) ParseToken(tokenString string) (*Claims, error) {
+	state := t.state.Load()
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != state.signingMethod() {
+			return nil, errors.New("auth: unexpected signing method")
+		}
+		return state.verifyKey(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("auth: invalid token")
+	}
+	return claims, nil
+}
+
+// HashPassword hashes password with the algorithm and cost configured on cfg.
+func HashPassword(password string, cfg *config.Config) (string, error) {
+	switch HashAlgorithm(cfg.PasswordHashAlgorithm) {
+	case HashAlgorithmArgon2id, "":
+		return hashPasswordArgon2id(password, cfg)
+	case HashAlgorithmBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), cfg.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	default:
+		return "", errors.New("auth: unsupported password hash algorithm " + cfg.PasswordHashAlgorithm)
+	}
+}
+
+// VerifyPassword reports whether password matches hash, regardless of which
+// of the supported algorithms produced it. cfg supplies the argon2id cost
+// parameters to verify against; it is ignored for bcrypt hashes, which carry
+// their own cost in the hash itself.
+func VerifyPassword(hash, password string, cfg *config.Config) (bool, error) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		return verifyPasswordArgon2id(hash, password, cfg)
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func hashPasswordArgon2id(password string, cfg *config.Config) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, cfg.ArgonTime, cfg.ArgonMemoryKiB, cfg.ArgonThreads, argon2idKeyLen)
+	enc := base64.RawURLEncoding
+	return "$argon2id$" + enc.EncodeToString(salt) + "$" + enc.EncodeToString(key), nil
+}
+
+func verifyPasswordArgon2id(hash, password string, cfg *config.Config) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 {
+		return false, errors.New("auth: malformed argon2id hash")
+	}
+	enc := base64.RawURLEncoding
+	salt, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return false, err
+	}
+	want, err := enc.DecodeString(parts[3])
+	if err != nil {
+		return false, err
+	}
+	got := argon2.IDKey([]byte(password), salt, cfg.ArgonTime, cfg.ArgonMemoryKiB, cfg.ArgonThreads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
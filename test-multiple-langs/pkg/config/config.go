@@ -0,0 +1,118 @@
+// Package config loads and validates runtime configuration for the server.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Port                  string
+	DatabaseURL           string
+	RedisURL              string
+	JWTSecret             string
+	JWTAlgorithm          string
+	JWTPrivateKeyPath     string
+	JWTAccessTTL          time.Duration
+	JWTRefreshTTL         time.Duration
+	PasswordHashAlgorithm string
+	BcryptCost            int
+	ArgonTime             uint32
+	ArgonMemoryKiB        uint32
+	ArgonThreads          uint8
+	Environment           Environment
+	AllowedOrigin         string
+	CacheTTL              time.Duration
+}
+
+// LoadConfig reads configuration from, in increasing precedence: a
+// config.{yaml,toml,json} file discovered by viper, a .env file in the
+// working directory, and the process environment. Values from .env are
+// loaded into the process environment without overriding variables that
+// are already set. It fails fast if the result is unsafe to run, e.g. a
+// default JWT secret in production.
+func LoadConfig() (*Config, error) {
+	_ = godotenv.Load() // .env is optional; process env still applies if absent
+
+	v := viper.New()
+	v.SetConfigName("config")
+	v.AddConfigPath(".")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	setDefaults(v)
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("config: reading config file: %w", err)
+		}
+	}
+
+	env, err := parseEnvironment(v.GetString("environment"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Port:                  v.GetString("port"),
+		DatabaseURL:           v.GetString("database_url"),
+		RedisURL:              v.GetString("redis_url"),
+		JWTSecret:             v.GetString("jwt_secret"),
+		JWTAlgorithm:          v.GetString("jwt_algorithm"),
+		JWTPrivateKeyPath:     v.GetString("jwt_private_key_path"),
+		JWTAccessTTL:          v.GetDuration("jwt_access_ttl"),
+		JWTRefreshTTL:         v.GetDuration("jwt_refresh_ttl"),
+		PasswordHashAlgorithm: v.GetString("password_hash_algorithm"),
+		BcryptCost:            v.GetInt("bcrypt_cost"),
+		ArgonTime:             v.GetUint32("argon_time"),
+		ArgonMemoryKiB:        v.GetUint32("argon_memory_kib"),
+		ArgonThreads:          v.GetUint8("argon_threads"),
+		Environment:           env,
+		AllowedOrigin:         v.GetString("allowed_origin"),
+		CacheTTL:              v.GetDuration("cache_ttl"),
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("port", "8080")
+	v.SetDefault("database_url", "postgres://localhost/testdb")
+	v.SetDefault("redis_url", "redis://localhost:6379")
+	v.SetDefault("jwt_secret", "secret")
+	v.SetDefault("jwt_algorithm", "HS256")
+	v.SetDefault("jwt_private_key_path", "")
+	v.SetDefault("jwt_access_ttl", 15*time.Minute)
+	v.SetDefault("jwt_refresh_ttl", 7*24*time.Hour)
+	v.SetDefault("password_hash_algorithm", "argon2id")
+	v.SetDefault("bcrypt_cost", 10)
+	v.SetDefault("argon_time", 1)
+	v.SetDefault("argon_memory_kib", 64*1024)
+	v.SetDefault("argon_threads", 4)
+	v.SetDefault("environment", string(EnvDevelopment))
+	v.SetDefault("allowed_origin", "*")
+	v.SetDefault("cache_ttl", 5*time.Minute)
+}
+
+// validate fails fast on configuration that would be unsafe to run.
+func (c *Config) validate() error {
+	if c.JWTAlgorithm == "HS256" && c.JWTSecret == "" {
+		return errors.New("config: JWT_SECRET must be set when JWT_ALGORITHM is HS256")
+	}
+	if c.Environment.IsProduction() {
+		if c.JWTSecret == "secret" {
+			return errors.New("config: JWT_SECRET must not be the default value \"secret\" in production")
+		}
+		if c.AllowedOrigin == "*" {
+			return errors.New("config: ALLOWED_ORIGIN must not be \"*\" in production")
+		}
+	}
+	return nil
+}
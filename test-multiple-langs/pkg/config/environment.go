@@ -0,0 +1,28 @@
+package config
+
+import "fmt"
+
+// Environment is the deployment tier the server is running in. It gates
+// behavior that should only ever be permissive outside of production, such
+// as the WebSocket upgrader's CheckOrigin and the CORS middleware.
+type Environment string
+
+const (
+	EnvDevelopment Environment = "development"
+	EnvStaging     Environment = "staging"
+	EnvProduction  Environment = "production"
+)
+
+// IsProduction reports whether e is the production tier.
+func (e Environment) IsProduction() bool {
+	return e == EnvProduction
+}
+
+func parseEnvironment(raw string) (Environment, error) {
+	switch Environment(raw) {
+	case EnvDevelopment, EnvStaging, EnvProduction:
+		return Environment(raw), nil
+	default:
+		return "", fmt.Errorf("config: unknown environment %q (want development, staging, or production)", raw)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "sync/atomic"
+
+// Store holds the most recently loaded Config behind an atomic pointer so
+// components built once at startup (CORS, the WebSocket upgrader) can
+// observe the result of a later Watch reload instead of closing over the
+// Config snapshot fx constructed them with.
+type Store struct {
+	ptr atomic.Pointer[Config]
+}
+
+// NewStore returns a Store seeded with initial.
+func NewStore(initial *Config) *Store {
+	s := &Store{}
+	s.ptr.Store(initial)
+	return s
+}
+
+// Get returns the Config most recently passed to Set, or the Config the
+// Store was constructed with if Set has never been called.
+func (s *Store) Get() *Config {
+	return s.ptr.Load()
+}
+
+// Set replaces the Config observed by Get.
+func (s *Store) Set(cfg *Config) {
+	s.ptr.Store(cfg)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Watch calls onReload with a freshly loaded Config whenever the process
+// receives SIGHUP or the discovered config file changes on disk, until ctx
+// is cancelled. It must run in its own goroutine.
+func (c *Config) Watch(ctx context.Context, onReload func(*Config)) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	v := viper.New()
+	v.SetConfigName("config")
+	v.AddConfigPath(".")
+	if err := v.ReadInConfig(); err == nil {
+		v.OnConfigChange(func(fsnotify.Event) {
+			if ctx.Err() != nil {
+				return
+			}
+			reload(onReload)
+		})
+		v.WatchConfig()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			reload(onReload)
+		}
+	}
+}
+
+func reload(onReload func(*Config)) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Printf("config: reload failed, keeping previous config: %v", err)
+		return
+	}
+	onReload(cfg)
+}
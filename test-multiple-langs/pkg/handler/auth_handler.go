@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/auth"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/service"
+)
+
+// AuthHandler exposes the register/login/refresh endpoints.
+type AuthHandler struct {
+	service *service.UserService
+	issuer  auth.TokenIssuer
+	config  *config.Config
+}
+
+func NewAuthHandler(svc *service.UserService, issuer auth.TokenIssuer, cfg *config.Config) *AuthHandler {
+	return &AuthHandler{service: svc, issuer: issuer, config: cfg}
+}
+
+type registerRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !validateEmail(req.Email) || req.Password == "" {
+		http.Error(w, "invalid email or password", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password, h.config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user := &model.User{
+		Name:         req.Name,
+		Email:        req.Email,
+		PasswordHash: hash,
+		Role:         "user",
+	}
+
+	ctx := r.Context()
+	if err := h.service.CreateUser(ctx, user); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.service.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	ok, err := auth.VerifyPassword(user.PasswordHash, req.Password, h.config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	h.writeTokenPair(w, user)
+}
+
+func (h *AuthHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, err := h.issuer.ParseToken(req.RefreshToken)
+	if err != nil {
+		http.Error(w, "invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+	if claims.TokenType != auth.TokenTypeRefresh {
+		http.Error(w, "invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid refresh token subject", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.service.GetUserByID(r.Context(), uint(id))
+	if err != nil {
+		http.Error(w, "user no longer exists", http.StatusUnauthorized)
+		return
+	}
+
+	h.writeTokenPair(w, user)
+}
+
+func (h *AuthHandler) writeTokenPair(w http.ResponseWriter, user *model.User) {
+	access, err := h.issuer.IssueAccessToken(user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	refresh, err := h.issuer.IssueRefreshToken(user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokenResponse{AccessToken: access, RefreshToken: refresh})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/auth"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/middleware"
+)
+
+// NewRouter wires the user and auth handlers into a mux.Router, gating the
+// mutating user routes behind middleware.JWT and an admin role requirement.
+func NewRouter(userHandler *UserHandler, authHandler *AuthHandler, issuer auth.TokenIssuer, store *config.Store) *mux.Router {
+	r := mux.NewRouter()
+
+	r.Use(middleware.Logging)
+	r.Use(middleware.CORS(store))
+
+	api := r.PathPrefix("/api/v1").Subrouter()
+
+	authAPI := api.PathPrefix("/auth").Subrouter()
+	authAPI.HandleFunc("/register", authHandler.HandleRegister).Methods("POST")
+	authAPI.HandleFunc("/login", authHandler.HandleLogin).Methods("POST")
+	authAPI.HandleFunc("/refresh", authHandler.HandleRefresh).Methods("POST")
+
+	api.HandleFunc("/users", userHandler.HandleGetUsers).Methods("GET")
+	api.HandleFunc("/users/{id}", userHandler.HandleGetUser).Methods("GET")
+
+	protected := api.PathPrefix("").Subrouter()
+	protected.Use(middleware.JWT(issuer))
+	protected.Use(middleware.RequireRole("admin"))
+	protected.HandleFunc("/users", userHandler.HandleCreateUser).Methods("POST")
+
+	// The Hub broadcasts real user data (name/email/role) to whatever topics
+	// a client subscribes to, so /ws needs the same authentication as the
+	// REST API before a client can open a connection at all.
+	r.Handle("/ws", middleware.JWT(issuer)(http.HandlerFunc(userHandler.HandleWebSocket)))
+
+	return r
+}
@@ -0,0 +1,164 @@
+// Package handler adapts HTTP requests to the service layer.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/middleware"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/service"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/worker"
+)
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func validateEmail(email string) bool {
+	return len(email) > 0 &&
+		len(email) <= 254 &&
+		emailRegex.MatchString(email)
+}
+
+type UserHandler struct {
+	service  *service.UserService
+	hub      *service.Hub
+	pool     *worker.WorkerPool
+	upgrader websocket.Upgrader
+}
+
+func NewUserHandler(svc *service.UserService, hub *service.Hub, pool *worker.WorkerPool, store *config.Store) *UserHandler {
+	return &UserHandler{
+		service: svc,
+		hub:     hub,
+		pool:    pool,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				// Read the Store on every upgrade so a config reload's
+				// environment/AllowedOrigin change takes effect immediately.
+				cfg := store.Get()
+				// Outside production, allow any origin so local tools (e.g. a
+				// dev frontend on a different port) can connect freely.
+				if !cfg.Environment.IsProduction() {
+					return true
+				}
+				return r.Header.Get("Origin") == cfg.AllowedOrigin
+			},
+		},
+	}
+}
+
+func (h *UserHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	users, err := h.service.GetAllUsers(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+func (h *UserHandler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.service.GetUserByID(ctx, uint(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *UserHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var user model.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.service.CreateUser(ctx, &user); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resultCh, err := h.pool.TrySubmit(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return nil, sendWelcomeEmail(ctx, &user)
+	})
+	if err != nil {
+		log.Printf("welcome email not queued for %s: %v", user.Email, err)
+	} else {
+		go func() {
+			if result := <-resultCh; result.Err != nil {
+				log.Printf("welcome email failed for %s: %v", user.Email, result.Err)
+			}
+		}()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+// sendWelcomeEmail is a placeholder for the outbound email integration; it
+// only needs to honor ctx cancellation here.
+func sendWelcomeEmail(ctx context.Context, user *model.User) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		log.Printf("welcome email sent to %s", user.Email)
+		return nil
+	}
+}
+
+func (h *UserHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.ClaimsFromContext(r.Context())
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid token subject", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+
+	client := service.NewClient(h.hub, conn, uint(userID), isAdminRole(claims.Roles))
+	client.Register()
+
+	go client.WritePump()
+	client.ReadPump()
+}
+
+func isAdminRole(roles []string) bool {
+	for _, role := range roles {
+		if role == "admin" {
+			return true
+		}
+	}
+	return false
+}
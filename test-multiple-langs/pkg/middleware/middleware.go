@@ -0,0 +1,40 @@
+// Package middleware holds the http.Handler wrappers shared by all routes.
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+)
+
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Printf("%s %s %v", r.Method, r.URL.Path, time.Since(start))
+	})
+}
+
+// CORS allows the current Store's AllowedOrigin (which LoadConfig refuses
+// to leave as "*" in production). Outside production it defaults to the
+// wide-open "*". It reads the Store on every request so a config reload
+// takes effect without restarting the server.
+func CORS(store *config.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := store.Get()
+			w.Header().Set("Access-Control-Allow-Origin", cfg.AllowedOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
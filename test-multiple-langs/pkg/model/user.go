@@ -0,0 +1,15 @@
+// Package model holds the domain types shared across the service, store, and
+// handler layers.
+package model
+
+import "time"
+
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role" gorm:"default:user"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+)
+
+// Cache is the subset of a Redis-like client UserService depends on, narrowed
+// to the service boundary so tests can inject an in-memory fake.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
+
+// CachePolicy controls how UserService reads and writes through its cache.
+type CachePolicy struct {
+	TTL      time.Duration
+	Disabled bool
+}
+
+// NewCachePolicy builds a CachePolicy from cfg.CacheTTL.
+func NewCachePolicy(cfg *config.Config) CachePolicy {
+	return CachePolicy{TTL: cfg.CacheTTL}
+}
+
+type skipCacheKey struct{}
+
+// WithoutCache returns a context that bypasses the read-through cache for
+// consistency-critical call sites, even when the service's policy enables it.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+func (s *UserService) cacheEnabled(ctx context.Context) bool {
+	if s.cachePolicy.Disabled || s.cache == nil {
+		return false
+	}
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return !skip
+}
+
+// cacheKindUser and cacheKindAllUsers are the Prometheus label values for
+// the cache metrics below. They classify a read by the shape of key it hit
+// (a single user vs. the all-users listing) rather than the key itself, so
+// cardinality stays bounded regardless of how many distinct user IDs are
+// ever cached.
+const (
+	cacheKindUser     = "user"
+	cacheKindAllUsers = "users:all"
+)
+
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_service_cache_hits_total",
+		Help: "Number of UserService cache reads served from the cache.",
+	}, []string{"kind"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_service_cache_misses_total",
+		Help: "Number of UserService cache reads that fell through to the database.",
+	}, []string{"kind"})
+)
+
+const cacheKeyAllUsers = "users:all"
+
+func cacheKeyUser(id uint) string {
+	return "user:" + strconv.FormatUint(uint64(id), 10)
+}
+
+// invalidateUserCache drops both the per-user entry and the all-users
+// listing, since a write to one user makes the listing stale too.
+func (s *UserService) invalidateUserCache(ctx context.Context, id uint) {
+	if s.cache == nil {
+		return
+	}
+	s.cache.Del(ctx, cacheKeyUser(id), cacheKeyAllUsers)
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+)
+
+const redisEventsChannel = "user-events"
+
+// Event types published by UserService whenever user data changes.
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+const userTopicPrefix = "user:"
+
+// TopicAllUsers is the topic clients subscribe to for every user change.
+func TopicAllUsers() string {
+	return "users"
+}
+
+// TopicUser is the topic clients subscribe to for changes to a single user.
+func TopicUser(id uint) string {
+	return fmt.Sprintf("%s%d", userTopicPrefix, id)
+}
+
+// parseUserTopic extracts the id from a TopicUser-shaped topic string.
+func parseUserTopic(topic string) (uint, bool) {
+	if !strings.HasPrefix(topic, userTopicPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(topic, userTopicPrefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// Event is the envelope broadcast to WebSocket clients and fanned out over Redis.
+type Event struct {
+	Type    string      `json:"type"`
+	Topic   string      `json:"topic"`
+	Payload interface{} `json:"payload"`
+}
+
+// subscribeFrame is the client-sent JSON frame used to (un)subscribe to topics,
+// e.g. {"action":"subscribe","topic":"users"}.
+type subscribeFrame struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// Client is a single WebSocket connection registered with a Hub, scoped to
+// the user it was authenticated as.
+type Client struct {
+	hub     *Hub
+	conn    *websocket.Conn
+	send    chan []byte
+	topics  map[string]bool
+	mu      sync.RWMutex
+	userID  uint
+	isAdmin bool
+}
+
+// NewClient wraps conn as a Hub client authenticated as userID, with isAdmin
+// reflecting whether that user holds the admin role. Callers must register
+// it with its Register method and start both pumps.
+func NewClient(hub *Hub, conn *websocket.Conn, userID uint, isAdmin bool) *Client {
+	return &Client{
+		hub:     hub,
+		conn:    conn,
+		send:    make(chan []byte, 256),
+		topics:  make(map[string]bool),
+		userID:  userID,
+		isAdmin: isAdmin,
+	}
+}
+
+// canSubscribe reports whether the client is allowed to receive events on
+// topic. Every client may subscribe to its own user:{id} topic; only admins
+// may subscribe to another user's topic. TopicAllUsers carries no more than
+// the REST API's unauthenticated GET /users listing already exposes, so any
+// authenticated client may subscribe to it.
+func (c *Client) canSubscribe(topic string) bool {
+	if c.isAdmin {
+		return true
+	}
+	if topic == TopicAllUsers() {
+		return true
+	}
+	if id, ok := parseUserTopic(topic); ok {
+		return id == c.userID
+	}
+	return false
+}
+
+// Register enqueues the client with its Hub.
+func (c *Client) Register() {
+	c.hub.register <- c
+}
+
+func (c *Client) subscribedTo(topic string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.topics[topic]
+}
+
+func (c *Client) handleFrame(raw []byte) {
+	var frame subscribeFrame
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch frame.Action {
+	case "subscribe":
+		if !c.canSubscribe(frame.Topic) {
+			return
+		}
+		c.topics[frame.Topic] = true
+	case "unsubscribe":
+		delete(c.topics, frame.Topic)
+	}
+}
+
+// ReadPump reads subscription frames off the connection until it errors or
+// closes, then unregisters the client. It must run in its own goroutine.
+func (c *Client) ReadPump() {
+	defer func() {
+		c.hub.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("websocket read error: %v", err)
+			}
+			break
+		}
+		c.handleFrame(raw)
+	}
+}
+
+// WritePump relays broadcast messages to the connection and keeps it alive
+// with periodic pings. It must run in its own goroutine.
+func (c *Client) WritePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Hub tracks connected WebSocket clients and broadcasts events to the ones
+// subscribed to a matching topic. Events published anywhere are sent through
+// Redis pub/sub first so every instance's Hub broadcasts them locally,
+// keeping multi-node deployments in sync.
+type Hub struct {
+	clients    map[*Client]bool
+	register   chan *Client
+	unregister chan *Client
+	broadcast  chan []byte
+	redis      *redis.Client
+}
+
+func NewHub(rdb *redis.Client) *Hub {
+	return &Hub{
+		clients:    make(map[*Client]bool),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		broadcast:  make(chan []byte, 256),
+		redis:      rdb,
+	}
+}
+
+// Run owns the clients map and must be started in its own goroutine before
+// any client registers. It returns when ctx is cancelled.
+func (h *Hub) Run(ctx context.Context) {
+	go h.subscribeRedis(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case c := <-h.register:
+			h.clients[c] = true
+		case c := <-h.unregister:
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				close(c.send)
+			}
+		case raw := <-h.broadcast:
+			h.dispatch(raw)
+		}
+	}
+}
+
+func (h *Hub) dispatch(raw []byte) {
+	var event Event
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return
+	}
+
+	for c := range h.clients {
+		if !c.subscribedTo(event.Topic) {
+			continue
+		}
+		select {
+		case c.send <- raw:
+		default:
+			delete(h.clients, c)
+			close(c.send)
+		}
+	}
+}
+
+// Publish fans event out over Redis so every node's Hub broadcasts it to its
+// own clients. If no Redis client is configured (e.g. in tests), it falls
+// back to broadcasting locally.
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if h.redis == nil {
+		select {
+		case h.broadcast <- raw:
+		default:
+		}
+		return nil
+	}
+
+	return h.redis.Publish(ctx, redisEventsChannel, raw).Err()
+}
+
+func (h *Hub) subscribeRedis(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+
+	sub := h.redis.Subscribe(ctx, redisEventsChannel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			select {
+			case h.broadcast <- []byte(msg.Payload):
+			default:
+				log.Printf("hub: dropping event, broadcast channel full")
+			}
+		}
+	}
+}
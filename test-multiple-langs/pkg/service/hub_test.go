@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestHubPublishFallsBackToLocalBroadcastWithoutRedis(t *testing.T) {
+	hub := NewHub(nil)
+
+	event := Event{Type: EventUserCreated, Topic: TopicAllUsers(), Payload: 1}
+	if err := hub.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case raw := <-hub.broadcast:
+		var got Event
+		if err := json.Unmarshal(raw, &got); err != nil {
+			t.Fatalf("unmarshal broadcast: %v", err)
+		}
+		if got.Type != event.Type || got.Topic != event.Topic {
+			t.Fatalf("got event %+v, want %+v", got, event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish did not fall back to the local broadcast channel")
+	}
+}
+
+func TestHubDispatchSendsOnlyToSubscribedClients(t *testing.T) {
+	hub := NewHub(nil)
+
+	subscribed := &Client{hub: hub, send: make(chan []byte, 1), topics: map[string]bool{"users": true}}
+	unsubscribed := &Client{hub: hub, send: make(chan []byte, 1), topics: map[string]bool{}}
+	hub.clients[subscribed] = true
+	hub.clients[unsubscribed] = true
+
+	raw, err := json.Marshal(Event{Type: EventUserCreated, Topic: "users", Payload: 1})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	hub.dispatch(raw)
+
+	select {
+	case got := <-subscribed.send:
+		if string(got) != string(raw) {
+			t.Fatalf("got %s, want %s", got, raw)
+		}
+	default:
+		t.Fatal("subscribed client did not receive the event")
+	}
+
+	select {
+	case got := <-unsubscribed.send:
+		t.Fatalf("unsubscribed client unexpectedly received %s", got)
+	default:
+	}
+}
+
+func TestHubDispatchDropsClientWithFullSendBuffer(t *testing.T) {
+	hub := NewHub(nil)
+
+	slow := &Client{hub: hub, send: make(chan []byte, 1), topics: map[string]bool{"users": true}}
+	slow.send <- []byte("stale") // fill the buffer so dispatch's non-blocking send can't enqueue
+	hub.clients[slow] = true
+
+	raw, err := json.Marshal(Event{Type: EventUserCreated, Topic: "users", Payload: 1})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	hub.dispatch(raw)
+
+	if _, ok := hub.clients[slow]; ok {
+		t.Fatal("dispatch did not drop the client with a full send buffer")
+	}
+	<-slow.send // drain the stale buffered message left over from before the drop
+	if _, ok := <-slow.send; ok {
+		t.Fatal("dispatch did not close the dropped client's send channel")
+	}
+}
+
+func TestHubDispatchIgnoresMalformedEvent(t *testing.T) {
+	hub := NewHub(nil)
+
+	client := &Client{hub: hub, send: make(chan []byte, 1), topics: map[string]bool{"users": true}}
+	hub.clients[client] = true
+
+	hub.dispatch([]byte("not json"))
+
+	select {
+	case got := <-client.send:
+		t.Fatalf("client unexpectedly received %s for a malformed event", got)
+	default:
+	}
+}
+
+func TestClientCanSubscribe(t *testing.T) {
+	owner := &Client{userID: 1}
+	other := &Client{userID: 2}
+	admin := &Client{userID: 2, isAdmin: true}
+
+	cases := []struct {
+		name  string
+		c     *Client
+		topic string
+		want  bool
+	}{
+		{"owner can subscribe to its own user topic", owner, TopicUser(1), true},
+		{"non-owner cannot subscribe to another user's topic", other, TopicUser(1), false},
+		{"admin can subscribe to any user's topic", admin, TopicUser(1), true},
+		{"any authenticated client can subscribe to the all-users topic", other, TopicAllUsers(), true},
+		{"unrecognized topic is denied for non-admins", other, "something-else", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.canSubscribe(tc.topic); got != tc.want {
+				t.Fatalf("canSubscribe(%q) = %v, want %v", tc.topic, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandleFrameRejectsSubscribeToAnotherUsersTopic(t *testing.T) {
+	hub := NewHub(nil)
+	client := &Client{hub: hub, userID: 1, send: make(chan []byte, 1), topics: make(map[string]bool)}
+
+	raw, err := json.Marshal(subscribeFrame{Action: "subscribe", Topic: TopicUser(2)})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	client.handleFrame(raw)
+
+	if client.subscribedTo(TopicUser(2)) {
+		t.Fatal("handleFrame subscribed the client to another user's topic")
+	}
+}
+
+func TestHandleFrameAllowsSubscribeToOwnTopic(t *testing.T) {
+	hub := NewHub(nil)
+	client := &Client{hub: hub, userID: 1, send: make(chan []byte, 1), topics: make(map[string]bool)}
+
+	raw, err := json.Marshal(subscribeFrame{Action: "subscribe", Topic: TopicUser(1)})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	client.handleFrame(raw)
+
+	if !client.subscribedTo(TopicUser(1)) {
+		t.Fatal("handleFrame did not subscribe the client to its own topic")
+	}
+}
@@ -0,0 +1,126 @@
+// Package service holds the business logic that sits between the HTTP
+// handlers and the storage/cache/event-bus boundaries.
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/store"
+)
+
+type UserService struct {
+	repo        store.UserRepository
+	cache       Cache
+	hub         *Hub
+	cachePolicy CachePolicy
+	sf          singleflight.Group
+}
+
+func NewUserService(repo store.UserRepository, cache Cache, hub *Hub, cachePolicy CachePolicy) *UserService {
+	return &UserService{
+		repo:        repo,
+		cache:       cache,
+		hub:         hub,
+		cachePolicy: cachePolicy,
+	}
+}
+
+func (s *UserService) GetAllUsers(ctx context.Context) ([]model.User, error) {
+	if !s.cacheEnabled(ctx) {
+		return s.repo.Find(ctx)
+	}
+
+	if raw, err := s.cache.Get(ctx, cacheKeyAllUsers); err == nil {
+		var users []model.User
+		if jsonErr := json.Unmarshal(raw, &users); jsonErr == nil {
+			cacheHits.WithLabelValues(cacheKindAllUsers).Inc()
+			return users, nil
+		}
+	}
+	cacheMisses.WithLabelValues(cacheKindAllUsers).Inc()
+
+	v, err, _ := s.sf.Do(cacheKeyAllUsers, func() (interface{}, error) {
+		users, err := s.repo.Find(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if raw, err := json.Marshal(users); err == nil {
+			s.cache.Set(ctx, cacheKeyAllUsers, raw, s.cachePolicy.TTL)
+		}
+		return users, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]model.User), nil
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*model.User, error) {
+	if !s.cacheEnabled(ctx) {
+		return s.repo.FindByID(ctx, id)
+	}
+
+	key := cacheKeyUser(id)
+	if raw, err := s.cache.Get(ctx, key); err == nil {
+		var user model.User
+		if jsonErr := json.Unmarshal(raw, &user); jsonErr == nil {
+			cacheHits.WithLabelValues(cacheKindUser).Inc()
+			return &user, nil
+		}
+	}
+	cacheMisses.WithLabelValues(cacheKindUser).Inc()
+
+	v, err, _ := s.sf.Do(key, func() (interface{}, error) {
+		user, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if raw, err := json.Marshal(user); err == nil {
+			s.cache.Set(ctx, key, raw, s.cachePolicy.TTL)
+		}
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*model.User), nil
+}
+
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return s.repo.FindByEmail(ctx, email)
+}
+
+func (s *UserService) CreateUser(ctx context.Context, user *model.User) error {
+	if err := s.repo.Create(ctx, user); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.Del(ctx, cacheKeyAllUsers)
+	}
+	s.hub.Publish(ctx, Event{Type: EventUserCreated, Topic: TopicAllUsers(), Payload: user})
+	return nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return err
+	}
+	s.invalidateUserCache(ctx, id)
+	s.hub.Publish(ctx, Event{Type: EventUserUpdated, Topic: TopicAllUsers(), Payload: updates})
+	s.hub.Publish(ctx, Event{Type: EventUserUpdated, Topic: TopicUser(id), Payload: updates})
+	return nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateUserCache(ctx, id)
+	s.hub.Publish(ctx, Event{Type: EventUserDeleted, Topic: TopicAllUsers(), Payload: id})
+	s.hub.Publish(ctx, Event{Type: EventUserDeleted, Topic: TopicUser(id), Payload: id})
+	return nil
+}
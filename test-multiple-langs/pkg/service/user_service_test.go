@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/store"
+)
+
+// fakeCache is an in-memory Cache used to exercise UserService's read-through
+// and invalidation logic without a real Redis instance.
+type fakeCache struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	getCalls map[string]int
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: make(map[string][]byte), getCalls: make(map[string]int)}
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.getCalls[key]++
+	v, ok := c.values[key]
+	if !ok {
+		return nil, errors.New("fakeCache: miss")
+	}
+	return v, nil
+}
+
+func (c *fakeCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *fakeCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range keys {
+		delete(c.values, k)
+	}
+	return nil
+}
+
+// fakeRepo is a store.UserRepository that counts FindByID calls so tests can
+// assert the cache actually avoided a repeat trip to the store.
+type fakeRepo struct {
+	mu          sync.Mutex
+	users       map[uint]model.User
+	findByIDHit int
+	findHit     int
+}
+
+func newFakeRepo(users ...model.User) *fakeRepo {
+	r := &fakeRepo{users: make(map[uint]model.User)}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *fakeRepo) Find(ctx context.Context) ([]model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.findHit++
+	users := make([]model.User, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (r *fakeRepo) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.findByIDHit++
+	u, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &u, nil
+}
+
+func (r *fakeRepo) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeRepo) Create(ctx context.Context, user *model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user.ID = uint(len(r.users) + 1)
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *fakeRepo) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if v, ok := updates["role"].(string); ok {
+		u.Role = v
+	}
+	r.users[id] = u
+	return nil
+}
+
+func (r *fakeRepo) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
+
+func (r *fakeRepo) List(ctx context.Context, filter store.ListFilter, page, size int) ([]model.User, error) {
+	return r.Find(ctx)
+}
+
+func newTestUserService(repo *fakeRepo, cache Cache) *UserService {
+	return NewUserService(repo, cache, NewHub(nil), CachePolicy{TTL: time.Minute})
+}
+
+func TestGetUserByIDCachesOnMiss(t *testing.T) {
+	repo := newFakeRepo(model.User{ID: 1, Name: "Ada", Email: "ada@example.com"})
+	cache := newFakeCache()
+	svc := newTestUserService(repo, cache)
+	ctx := context.Background()
+
+	if _, err := svc.GetUserByID(ctx, 1); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if _, err := svc.GetUserByID(ctx, 1); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+
+	if repo.findByIDHit != 1 {
+		t.Fatalf("got %d repo.FindByID calls, want 1 (second read should hit cache)", repo.findByIDHit)
+	}
+}
+
+func TestGetUserByIDBypassesCacheWithoutCache(t *testing.T) {
+	repo := newFakeRepo(model.User{ID: 1, Name: "Ada", Email: "ada@example.com"})
+	cache := newFakeCache()
+	svc := newTestUserService(repo, cache)
+	ctx := WithoutCache(context.Background())
+
+	if _, err := svc.GetUserByID(ctx, 1); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if _, err := svc.GetUserByID(ctx, 1); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+
+	if repo.findByIDHit != 2 {
+		t.Fatalf("got %d repo.FindByID calls, want 2 (WithoutCache should skip the cache both times)", repo.findByIDHit)
+	}
+}
+
+func TestUpdateUserInvalidatesCache(t *testing.T) {
+	repo := newFakeRepo(model.User{ID: 1, Name: "Ada", Email: "ada@example.com", Role: "user"})
+	cache := newFakeCache()
+	svc := newTestUserService(repo, cache)
+	ctx := context.Background()
+
+	if _, err := svc.GetUserByID(ctx, 1); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if err := svc.UpdateUser(ctx, 1, map[string]interface{}{"role": "admin"}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	got, err := svc.GetUserByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if got.Role != "admin" {
+		t.Fatalf("got role %q, want %q (stale cache entry was not invalidated)", got.Role, "admin")
+	}
+	if repo.findByIDHit != 2 {
+		t.Fatalf("got %d repo.FindByID calls, want 2 (post-update read should miss the invalidated cache)", repo.findByIDHit)
+	}
+}
+
+func TestGetAllUsersConcurrentMissesCoalesceViaSingleflight(t *testing.T) {
+	repo := newFakeRepo(model.User{ID: 1, Name: "Ada", Email: "ada@example.com"})
+	cache := newFakeCache()
+	svc := newTestUserService(repo, cache)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.GetAllUsers(ctx); err != nil {
+				t.Errorf("GetAllUsers: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cache.mu.Lock()
+	misses := cache.getCalls[cacheKeyAllUsers]
+	cache.mu.Unlock()
+	if misses != 10 {
+		t.Fatalf("got %d cache.Get calls, want 10 (one per caller)", misses)
+	}
+
+	repo.mu.Lock()
+	finds := repo.findHit
+	repo.mu.Unlock()
+	if finds != 1 {
+		t.Fatalf("got %d repo.Find calls, want 1 (singleflight should coalesce concurrent misses)", finds)
+	}
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+)
+
+// NewRepository opens the backing store selected by cfg.DatabaseURL's scheme
+// (postgres://, sqlite://, memory://), runs pending migrations where the
+// backend has a schema to migrate, and returns the resulting UserRepository.
+func NewRepository(cfg *config.Config) (UserRepository, error) {
+	switch {
+	case strings.HasPrefix(cfg.DatabaseURL, "postgres://"):
+		db, err := NewPostgresDB(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := RunMigrations(db, DialectPostgres); err != nil {
+			return nil, err
+		}
+		return NewPostgresRepository(db), nil
+
+	case strings.HasPrefix(cfg.DatabaseURL, "sqlite://"):
+		db, err := NewSQLiteDB(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := RunMigrations(db, DialectSQLite); err != nil {
+			return nil, err
+		}
+		return NewSQLiteRepository(db), nil
+
+	case strings.HasPrefix(cfg.DatabaseURL, "memory://"):
+		return NewMemoryRepository(), nil
+
+	default:
+		return nil, fmt.Errorf("store: unsupported database URL %q", cfg.DatabaseURL)
+	}
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+)
+
+// gormRepo is the gorm-backed UserRepository shared by the Postgres and
+// SQLite drivers; the two differ only in how their *gorm.DB is opened.
+type gormRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormRepo) Find(ctx context.Context) ([]model.User, error) {
+	var users []model.User
+	err := r.db.WithContext(ctx).Find(&users).Error
+	return users, err
+}
+
+func (r *gormRepo) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormRepo) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormRepo) Create(ctx context.Context, user *model.User) error {
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *gormRepo) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *gormRepo) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&model.User{}, id).Error
+}
+
+func (r *gormRepo) List(ctx context.Context, filter ListFilter, page, size int) ([]model.User, error) {
+	query := r.db.WithContext(ctx).Model(&model.User{})
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 20
+	}
+
+	var users []model.User
+	err := query.Offset((page - 1) * size).Limit(size).Find(&users).Error
+	return users, err
+}
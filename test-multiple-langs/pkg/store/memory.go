@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+)
+
+// memoryRepo is an in-memory UserRepository for unit tests and local
+// development; it keeps no state beyond the process and has no durability.
+type memoryRepo struct {
+	mu     sync.RWMutex
+	users  map[uint]model.User
+	nextID uint
+}
+
+// NewMemoryRepository returns an empty in-memory UserRepository.
+func NewMemoryRepository() UserRepository {
+	return &memoryRepo{users: make(map[uint]model.User)}
+}
+
+func (r *memoryRepo) Find(ctx context.Context) ([]model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]model.User, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (r *memoryRepo) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (r *memoryRepo) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, u := range r.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) Create(ctx context.Context, user *model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	user.ID = r.nextID
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryRepo) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	applyUpdates(&user, updates)
+	user.UpdatedAt = time.Now()
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryRepo) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
+
+func (r *memoryRepo) List(ctx context.Context, filter ListFilter, page, size int) ([]model.User, error) {
+	users, _ := r.Find(ctx)
+	if filter.Role != "" {
+		filtered := users[:0]
+		for _, u := range users {
+			if u.Role == filter.Role {
+				filtered = append(filtered, u)
+			}
+		}
+		users = filtered
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 20
+	}
+	start := (page - 1) * size
+	if start >= len(users) {
+		return []model.User{}, nil
+	}
+	end := start + size
+	if end > len(users) {
+		end = len(users)
+	}
+	return users[start:end], nil
+}
+
+// applyUpdates mirrors the subset of (*gorm.DB).Updates semantics the
+// gorm-backed repositories rely on: only known columns are settable.
+func applyUpdates(user *model.User, updates map[string]interface{}) {
+	if v, ok := updates["name"].(string); ok {
+		user.Name = v
+	}
+	if v, ok := updates["email"].(string); ok {
+		user.Email = v
+	}
+	if v, ok := updates["password_hash"].(string); ok {
+		user.PasswordHash = v
+	}
+	if v, ok := updates["role"].(string); ok {
+		user.Role = v
+	}
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+)
+
+func TestMemoryRepoCreateAssignsIDAndTimestamps(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	user := &model.User{Name: "Ada", Email: "ada@example.com"}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if user.ID == 0 {
+		t.Fatal("Create did not assign an ID")
+	}
+	if user.CreatedAt.IsZero() || user.UpdatedAt.IsZero() {
+		t.Fatal("Create did not set CreatedAt/UpdatedAt")
+	}
+
+	second := &model.User{Name: "Bob", Email: "bob@example.com"}
+	if err := repo.Create(ctx, second); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if second.ID == user.ID {
+		t.Fatalf("second Create reused ID %d", second.ID)
+	}
+}
+
+func TestMemoryRepoFindByIDNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if _, err := repo.FindByID(context.Background(), 1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("got err %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestMemoryRepoFindByEmail(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	user := &model.User{Name: "Ada", Email: "ada@example.com"}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := repo.FindByEmail(ctx, "ada@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail: %v", err)
+	}
+	if got.ID != user.ID {
+		t.Fatalf("got user %d, want %d", got.ID, user.ID)
+	}
+
+	if _, err := repo.FindByEmail(ctx, "nobody@example.com"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("got err %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestMemoryRepoUpdateAndDelete(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	user := &model.User{Name: "Ada", Email: "ada@example.com", Role: "user"}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := repo.Update(ctx, user.ID, map[string]interface{}{"role": "admin"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := repo.FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Role != "admin" {
+		t.Fatalf("got role %q, want %q", got.Role, "admin")
+	}
+
+	if err := repo.Update(ctx, 999, map[string]interface{}{"role": "admin"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("got err %v, want gorm.ErrRecordNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, user.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("got err %v, want gorm.ErrRecordNotFound after Delete", err)
+	}
+	if err := repo.Delete(ctx, user.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("got err %v, want gorm.ErrRecordNotFound on double Delete", err)
+	}
+}
+
+func TestMemoryRepoListFiltersAndPaginates(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		role := "user"
+		if i%2 == 0 {
+			role = "admin"
+		}
+		if err := repo.Create(ctx, &model.User{Name: "u", Email: "u", Role: role}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	admins, err := repo.List(ctx, ListFilter{Role: "admin"}, 1, 20)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(admins) != 3 {
+		t.Fatalf("got %d admins, want 3", len(admins))
+	}
+
+	page, err := repo.List(ctx, ListFilter{}, 1, 2)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("got page size %d, want 2", len(page))
+	}
+
+	empty, err := repo.List(ctx, ListFilter{}, 10, 2)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Fatalf("got %d users on out-of-range page, want 0", len(empty))
+	}
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrations embed.FS
+
+// Dialect selects the SQL dialect goose uses to run the embedded migrations.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite3"
+)
+
+// migrationDirs maps each dialect to its own migrations directory. The two
+// engines need distinct schema SQL (e.g. the primary key's auto-increment
+// syntax), so migrations are not shared across dialects.
+var migrationDirs = map[Dialect]string{
+	DialectPostgres: "migrations/postgres",
+	DialectSQLite:   "migrations/sqlite",
+}
+
+// RunMigrations applies any pending versioned migrations embedded under the
+// dialect's directory in migrationDirs to db using goose.
+func RunMigrations(db *gorm.DB, dialect Dialect) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	dir, ok := migrationDirs[dialect]
+	if !ok {
+		return fmt.Errorf("store: no migrations directory registered for dialect %q", dialect)
+	}
+
+	goose.SetBaseFS(migrations)
+	defer goose.SetBaseFS(nil)
+
+	if err := goose.SetDialect(string(dialect)); err != nil {
+		return err
+	}
+	return goose.Up(sqlDB, dir)
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+)
+
+// NewPostgresDB opens a gorm connection to cfg.DatabaseURL.
+func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
+}
+
+// NewPostgresRepository wraps db as a UserRepository.
+func NewPostgresRepository(db *gorm.DB) UserRepository {
+	return &gormRepo{db: db}
+}
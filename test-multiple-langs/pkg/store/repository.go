@@ -0,0 +1,25 @@
+// Package store defines the persistence boundary for user data.
+package store
+
+import (
+	"context"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/model"
+)
+
+// ListFilter narrows the result of UserRepository.List.
+type ListFilter struct {
+	Role string
+}
+
+// UserRepository abstracts user persistence so UserService can be tested
+// against a fake without a live database.
+type UserRepository interface {
+	Find(ctx context.Context) ([]model.User, error)
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	Create(ctx context.Context, user *model.User) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, filter ListFilter, page, size int) ([]model.User, error)
+}
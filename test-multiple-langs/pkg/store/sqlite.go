@@ -0,0 +1,22 @@
+package store
+
+import (
+	"strings"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/Dhiren-Mhatre/codity-assignment/test-multiple-langs/pkg/config"
+)
+
+// NewSQLiteDB opens a gorm connection backed by the file path encoded in
+// cfg.DatabaseURL (sqlite://path/to/file.db), for local development.
+func NewSQLiteDB(cfg *config.Config) (*gorm.DB, error) {
+	path := strings.TrimPrefix(cfg.DatabaseURL, "sqlite://")
+	return gorm.Open(sqlite.Open(path), &gorm.Config{})
+}
+
+// NewSQLiteRepository wraps db as a UserRepository.
+func NewSQLiteRepository(db *gorm.DB) UserRepository {
+	return &gormRepo{db: db}
+}
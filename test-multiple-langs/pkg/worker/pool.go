@@ -0,0 +1,209 @@
+// Package worker runs background tasks off a fixed pool of goroutines,
+// reporting their outcome back to the caller instead of firing and forgetting.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrPoolStopped is returned by Submit/TrySubmit once the pool has been
+// stopped or is draining.
+var ErrPoolStopped = errors.New("worker: pool is stopped")
+
+// ErrQueueFull is returned by TrySubmit when the task queue has no free
+// slot for the task.
+var ErrQueueFull = errors.New("worker: queue is full")
+
+// Task is a unit of work submitted to a WorkerPool. It must honor ctx
+// cancellation; the pool cannot forcibly stop a running goroutine.
+type Task func(ctx context.Context) (interface{}, error)
+
+// Result is the outcome of a Task, delivered on the channel returned by
+// Submit/TrySubmit.
+type Result struct {
+	Value interface{}
+	Err   error
+}
+
+type job struct {
+	ctx    context.Context
+	task   Task
+	result chan Result
+}
+
+// WorkerPool runs submitted Tasks on a fixed number of goroutines.
+type WorkerPool struct {
+	workers   int
+	queue     chan job
+	wg        sync.WaitGroup
+	active    int64
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+var (
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "worker_pool_queue_depth",
+		Help: "Number of tasks waiting in the worker pool queue.",
+	})
+
+	activeWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "worker_pool_active_workers",
+		Help: "Number of worker pool goroutines currently running a task.",
+	})
+
+	rejectedTasks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "worker_pool_rejected_tasks_total",
+		Help: "Number of tasks rejected because the queue was full or the pool was stopped.",
+	})
+)
+
+// NewWorkerPool builds a pool of workers goroutines backed by a 100-slot queue.
+func NewWorkerPool(workers int) *WorkerPool {
+	return &WorkerPool{
+		workers: workers,
+		queue:   make(chan job, 100),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start launches the pool's worker goroutines. It must be called once,
+// before the first Submit.
+func (wp *WorkerPool) Start() {
+	for i := 0; i < wp.workers; i++ {
+		wp.wg.Add(1)
+		go wp.worker()
+	}
+}
+
+// Submit enqueues task, blocking until a slot is free, ctx is cancelled, or
+// the pool is stopped. It returns a channel that receives exactly one
+// Result once the task finishes.
+func (wp *WorkerPool) Submit(ctx context.Context, task Task) (<-chan Result, error) {
+	j := job{ctx: ctx, task: task, result: make(chan Result, 1)}
+
+	select {
+	case <-wp.done:
+		rejectedTasks.Inc()
+		return nil, ErrPoolStopped
+	default:
+	}
+
+	select {
+	case wp.queue <- j:
+		queueDepth.Set(float64(len(wp.queue)))
+		return j.result, nil
+	case <-ctx.Done():
+		rejectedTasks.Inc()
+		return nil, ctx.Err()
+	case <-wp.done:
+		rejectedTasks.Inc()
+		return nil, ErrPoolStopped
+	}
+}
+
+// TrySubmit enqueues task without blocking, failing with ErrQueueFull if
+// the queue has no free slot and ErrPoolStopped if the pool is stopped.
+func (wp *WorkerPool) TrySubmit(ctx context.Context, task Task) (<-chan Result, error) {
+	select {
+	case <-wp.done:
+		rejectedTasks.Inc()
+		return nil, ErrPoolStopped
+	default:
+	}
+
+	j := job{ctx: ctx, task: task, result: make(chan Result, 1)}
+	select {
+	case wp.queue <- j:
+		queueDepth.Set(float64(len(wp.queue)))
+		return j.result, nil
+	default:
+		rejectedTasks.Inc()
+		return nil, ErrQueueFull
+	}
+}
+
+// Stop stops accepting new tasks and waits for in-flight and queued tasks to
+// drain, or for ctx to be done, whichever comes first.
+//
+// wp.queue is never closed: a Submit/TrySubmit that passes its "is the pool
+// stopped" check concurrently with Stop could otherwise land on a send to an
+// already-closed queue and panic. Workers instead stop pulling new work once
+// wp.done is closed, draining whatever is already queued before exiting.
+func (wp *WorkerPool) Stop(ctx context.Context) error {
+	wp.closeOnce.Do(func() {
+		close(wp.done)
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		wp.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (wp *WorkerPool) worker() {
+	defer wp.wg.Done()
+	for {
+		select {
+		case j := <-wp.queue:
+			wp.runJob(j)
+		case <-wp.done:
+			wp.drainQueue()
+			return
+		}
+	}
+}
+
+// drainQueue runs any tasks already queued at the time the pool was
+// stopped, without blocking for new ones.
+func (wp *WorkerPool) drainQueue() {
+	for {
+		select {
+		case j := <-wp.queue:
+			wp.runJob(j)
+		default:
+			return
+		}
+	}
+}
+
+func (wp *WorkerPool) runJob(j job) {
+	queueDepth.Set(float64(len(wp.queue)))
+	atomic.AddInt64(&wp.active, 1)
+	activeWorkers.Set(float64(atomic.LoadInt64(&wp.active)))
+
+	j.result <- wp.run(j)
+
+	atomic.AddInt64(&wp.active, -1)
+	activeWorkers.Set(float64(atomic.LoadInt64(&wp.active)))
+}
+
+func (wp *WorkerPool) run(j job) (result Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Result{Err: fmt.Errorf("worker: task panicked: %v", r)}
+		}
+	}()
+
+	if err := j.ctx.Err(); err != nil {
+		return Result{Err: err}
+	}
+
+	value, err := j.task(j.ctx)
+	return Result{Value: value, Err: err}
+}
@@ -0,0 +1,124 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubmitReturnsResult(t *testing.T) {
+	wp := NewWorkerPool(2)
+	wp.Start()
+	defer wp.Stop(context.Background())
+
+	resultCh, err := wp.Submit(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	result := <-resultCh
+	if result.Err != nil {
+		t.Fatalf("unexpected task error: %v", result.Err)
+	}
+	if result.Value != 42 {
+		t.Fatalf("got value %v, want 42", result.Value)
+	}
+}
+
+func TestSubmitRecoversPanic(t *testing.T) {
+	wp := NewWorkerPool(1)
+	wp.Start()
+	defer wp.Stop(context.Background())
+
+	resultCh, err := wp.Submit(context.Background(), func(ctx context.Context) (interface{}, error) {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	result := <-resultCh
+	if result.Err == nil {
+		t.Fatal("expected panic to surface as an error, got nil")
+	}
+}
+
+func TestSubmitHonorsContextCancellation(t *testing.T) {
+	wp := NewWorkerPool(1)
+	wp.Start()
+	defer wp.Stop(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Submit's select between the queue and ctx.Done() is non-deterministic
+	// when both are immediately ready, so cancellation may be observed either
+	// as Submit's own return error or as the task's Result once it runs.
+	resultCh, err := wp.Submit(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got err %v, want context.Canceled", err)
+		}
+		return
+	}
+
+	result := <-resultCh
+	if !errors.Is(result.Err, context.Canceled) {
+		t.Fatalf("got err %v, want context.Canceled", result.Err)
+	}
+}
+
+func TestTrySubmitRejectsAfterStop(t *testing.T) {
+	wp := NewWorkerPool(1)
+	wp.Start()
+	if err := wp.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if _, err := wp.TrySubmit(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	}); !errors.Is(err, ErrPoolStopped) {
+		t.Fatalf("got err %v, want ErrPoolStopped", err)
+	}
+}
+
+// TestConcurrentSubmitDuringStopDoesNotPanic races Submit/TrySubmit against
+// Stop (which never closes the queue): they must never panic the caller's
+// goroutine, whatever the outcome of the race.
+func TestConcurrentSubmitDuringStopDoesNotPanic(t *testing.T) {
+	wp := NewWorkerPool(4)
+	wp.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Submit/TrySubmit panicked: %v", r)
+				}
+			}()
+			_, _ = wp.Submit(context.Background(), func(ctx context.Context) (interface{}, error) {
+				return nil, nil
+			})
+			_, _ = wp.TrySubmit(context.Background(), func(ctx context.Context) (interface{}, error) {
+				return nil, nil
+			})
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := wp.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	wg.Wait()
+}